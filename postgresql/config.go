@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	_ "github.com/lib/pq"
+)
+
+// Config holds the provider-level connection settings used to build a
+// *Client for every resource and data source.
+type Config struct {
+	Host            string
+	Port            int
+	Username        string
+	Password        string
+	SSLMode         string
+	ApplicationName string
+	ConnectTimeout  int
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// Client wraps a pooled *sql.DB along with the detected server
+// version, which several resources need in order to fold
+// version-specific privileges (e.g. MAINTAIN, added in PostgreSQL 17).
+type Client struct {
+	config  Config
+	db      *sql.DB
+	version semver.Version
+}
+
+// DB returns the underlying database handle for the connected database.
+func (c *Client) DB() *sql.DB {
+	return c.db
+}
+
+// clientPool caches one *Client per (host, database, user) tuple for
+// the lifetime of a single Terraform run, so the many resources and
+// data sources a large state touches share connections instead of
+// each dialing (and leaving open) its own *sql.DB. Guarded by a mutex
+// since resource CRUD runs concurrently across the provider's worker
+// pool.
+var clientPool = struct {
+	sync.Mutex
+	clients map[string]*Client
+}{clients: map[string]*Client{}}
+
+// clientPoolKey must capture every Config field that changes how or
+// who we connect as, not just the (host, database, user) tuple named
+// in the pool's doc comment: two aliased provider blocks that share
+// that tuple but differ in password, sslmode, or timeouts must not be
+// handed each other's connection.
+func clientPoolKey(c *Config, database string) string {
+	return fmt.Sprintf(
+		"%s:%d/%s?user=%s&password=%s&sslmode=%s&connect_timeout=%d",
+		c.Host, c.Port, database, c.Username, c.Password, c.SSLMode, c.ConnectTimeout,
+	)
+}
+
+// NewClient returns the pooled *Client for (host, database, user),
+// dialing and caching a new one on first use. The returned client is
+// shared across callers, so nothing here may mutate it after the
+// initial connect.
+func (c *Config) NewClient(database string) (*Client, error) {
+	key := clientPoolKey(c, database)
+
+	clientPool.Lock()
+	defer clientPool.Unlock()
+
+	if client, ok := clientPool.clients[key]; ok {
+		return client, nil
+	}
+
+	db, err := sql.Open("postgres", c.connStr(database))
+	if err != nil {
+		return nil, fmt.Errorf("could not open connection to database %q: %w", database, err)
+	}
+
+	if c.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(c.MaxOpenConns)
+	}
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+
+	version, err := fetchServerVersion(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	client := &Client{config: *c, db: db, version: version}
+	clientPool.clients[key] = client
+	return client, nil
+}
+
+func (c *Config) connStr(database string) string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s application_name=%s connect_timeout=%d",
+		c.Host, c.Port, c.Username, c.Password, database, c.SSLMode, c.ApplicationName, c.ConnectTimeout,
+	)
+}
+
+// fetchServerVersion queries `SHOW server_version` and parses it into
+// a semver.Version so callers can compare against version-gated
+// features (e.g. the PG17 MAINTAIN privilege).
+func fetchServerVersion(db *sql.DB) (semver.Version, error) {
+	var raw string
+	if err := db.QueryRow("SHOW server_version").Scan(&raw); err != nil {
+		return semver.Version{}, fmt.Errorf("could not determine server version: %w", err)
+	}
+
+	parsed, err := semver.ParseTolerant(raw)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("could not parse server version %q: %w", raw, err)
+	}
+	return parsed, nil
+}
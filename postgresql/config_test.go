@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// BenchmarkGrantPoolReuse stands up N postgresql_grant resources
+// against distinct roles in the same database and asserts the
+// resulting pg_stat_activity entries for this provider stay bounded
+// by max_open_conns regardless of N, guarding against the old
+// per-CRUD-call sql.Open behaviour creeping back in and exhausting
+// max_connections on large states. Requires a live server on port 5432
+// reachable via PGHOST, PGUSER and PGPASSWORD; each iteration creates
+// and tears down a real role and grant, so point it at a disposable
+// database.
+func BenchmarkGrantPoolReuse(b *testing.B) {
+	if os.Getenv("PGHOST") == "" {
+		b.Skip("set PGHOST (and friends) to run against a live server")
+	}
+
+	config := &Config{
+		Host:            os.Getenv("PGHOST"),
+		Port:            5432,
+		Username:        os.Getenv("PGUSER"),
+		Password:        os.Getenv("PGPASSWORD"),
+		SSLMode:         "prefer",
+		ApplicationName: "terraform-provider-postgresql",
+		MaxOpenConns:    4,
+		MaxIdleConns:    4,
+	}
+
+	admin, err := config.NewClient("postgres")
+	if err != nil {
+		b.Fatalf("NewClient: %s", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		roleName := fmt.Sprintf("bench_grant_role_%d", i)
+		if _, err := admin.DB().Exec(fmt.Sprintf("CREATE ROLE %s", quoteIdentifier(roleName))); err != nil {
+			b.Fatalf("could not create role %q: %s", roleName, err)
+		}
+		b.Cleanup(func() {
+			admin.DB().Exec(fmt.Sprintf("DROP ROLE IF EXISTS %s", quoteIdentifier(roleName)))
+		})
+
+		d := resourcePostgreSQLGrant().Data(nil)
+		d.Set("role", roleName)
+		d.Set("database", "postgres")
+		d.Set("schema", "public")
+		d.Set("object_type", "schema")
+		d.Set("objects", []interface{}{"public"})
+		d.Set("privileges", []interface{}{"USAGE"})
+
+		if diags := resourcePostgreSQLGrantCreate(nil, d, config); diags.HasError() {
+			b.Fatalf("resourcePostgreSQLGrantCreate: %v", diags)
+		}
+	}
+
+	var activityCount int
+	err = admin.DB().QueryRow(
+		"SELECT count(*) FROM pg_stat_activity WHERE application_name = $1",
+		"terraform-provider-postgresql",
+	).Scan(&activityCount)
+	if err != nil {
+		b.Fatalf("could not read pg_stat_activity: %s", err)
+	}
+
+	assert.LessOrEqual(b, activityCount, config.MaxOpenConns+1)
+}
@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgreSQLDatabase() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgreSQLDatabaseRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the database to look up.",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Owner of the database.",
+			},
+			"encoding": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Character set encoding of the database.",
+			},
+			"connection_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of concurrent connections allowed, -1 meaning no limit.",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLDatabaseRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	databaseName := d.Get("name").(string)
+
+	db, err := config.NewClient("postgres")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var (
+		owner     string
+		encoding  string
+		connLimit int
+	)
+	err = db.DB().QueryRow(
+		`SELECT pg_catalog.pg_get_userbyid(d.datdba), pg_catalog.pg_encoding_to_char(d.encoding), d.datconnlimit
+		 FROM pg_catalog.pg_database d WHERE d.datname = $1`,
+		databaseName,
+	).Scan(&owner, &encoding, &connLimit)
+	if err != nil {
+		return diag.Errorf("could not read database %q: %s", databaseName, err)
+	}
+
+	d.SetId(databaseName)
+	d.Set("owner", owner)
+	d.Set("encoding", encoding)
+	d.Set("connection_limit", connLimit)
+
+	return nil
+}
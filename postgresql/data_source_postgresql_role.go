@@ -0,0 +1,133 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgreSQLRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgreSQLRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role to look up.",
+			},
+			"superuser": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role has superuser privileges.",
+			},
+			"create_database": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is permitted to create databases.",
+			},
+			"create_role": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is permitted to create other roles.",
+			},
+			"login": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is permitted to log in.",
+			},
+			"connection_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of concurrent connections the role can make, -1 meaning no limit.",
+			},
+			"valid_until": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time after which the role's password is no longer valid.",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of roles this role is a member of.",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLRoleRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	roleName := d.Get("name").(string)
+
+	db, err := config.NewClient("postgres")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var (
+		rolsuper, rolcreatedb, rolcreaterole, rolcanlogin bool
+		rolconnlimit                                      int
+		rolvaliduntil                                     sql.NullTime
+	)
+	err = db.DB().QueryRow(
+		`SELECT rolsuper, rolcreatedb, rolcreaterole, rolcanlogin, rolconnlimit, rolvaliduntil
+		 FROM pg_roles WHERE rolname = $1`,
+		roleName,
+	).Scan(&rolsuper, &rolcreatedb, &rolcreaterole, &rolcanlogin, &rolconnlimit, &rolvaliduntil)
+	if err != nil {
+		return diag.Errorf("could not read role %q: %s", roleName, err)
+	}
+
+	memberOf, err := readRoleMemberships(db.DB(), roleName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	validUntil := ""
+	if rolvaliduntil.Valid {
+		validUntil = rolvaliduntil.Time.Format(time.RFC3339)
+	}
+
+	d.SetId(roleName)
+	d.Set("superuser", rolsuper)
+	d.Set("create_database", rolcreatedb)
+	d.Set("create_role", rolcreaterole)
+	d.Set("login", rolcanlogin)
+	d.Set("connection_limit", rolconnlimit)
+	d.Set("valid_until", validUntil)
+	d.Set("roles", memberOf)
+
+	return nil
+}
+
+// readRoleMemberships returns the names of every role roleName is a
+// member of, i.e. the roles granted to it via `GRANT role TO roleName`.
+func readRoleMemberships(db *sql.DB, roleName string) ([]string, error) {
+	rows, err := db.Query(
+		`SELECT r.rolname
+		 FROM pg_auth_members m
+		 JOIN pg_roles r ON r.oid = m.roleid
+		 JOIN pg_roles member ON member.oid = m.member
+		 WHERE member.rolname = $1`,
+		roleName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not read role memberships for %q: %w", roleName, err)
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		roles = append(roles, name)
+	}
+	return roles, rows.Err()
+}
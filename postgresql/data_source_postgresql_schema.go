@@ -0,0 +1,58 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgreSQLSchema() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgreSQLSchemaRead,
+
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Database to look the schema up in.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schema to look up.",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Owner of the schema.",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLSchemaRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	database := d.Get("database").(string)
+	schemaName := d.Get("name").(string)
+
+	db, err := config.NewClient(database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var owner string
+	err = db.DB().QueryRow(
+		`SELECT pg_catalog.pg_get_userbyid(n.nspowner)
+		 FROM pg_catalog.pg_namespace n WHERE n.nspname = $1`,
+		schemaName,
+	).Scan(&owner)
+	if err != nil {
+		return diag.Errorf("could not read schema %q in database %q: %s", schemaName, database, err)
+	}
+
+	d.SetId(database + "." + schemaName)
+	d.Set("owner", owner)
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package postgresql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findStringSubmatchMap runs a named-group regexp against subject and
+// returns the captured groups keyed by their names. Unnamed groups are
+// omitted, and the map is empty when subject does not match; a named
+// group that takes no part in a successful match maps to "".
+func findStringSubmatchMap(exp, subject string) map[string]string {
+	re := regexp.MustCompile(exp)
+	match := re.FindStringSubmatch(subject)
+
+	result := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if i == 0 || name == "" || i >= len(match) {
+			continue
+		}
+		result[name] = match[i]
+	}
+	return result
+}
+
+// quoteTableName double-quotes every dot-separated part of a
+// (optionally schema-qualified) table name so it can be safely
+// interpolated into generated SQL, e.g. "test.users" -> `"test"."users"`.
+func quoteTableName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = quoteIdentifier(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteIdentifier double-quotes a single SQL identifier, doubling any
+// embedded double quote per the SQL standard. This is NOT the same as
+// Go's %q (which backslash-escapes), and object names read back from
+// pg_class/pg_namespace are not trusted input, so the distinction
+// matters: a role with CREATE on a schema could otherwise plant an
+// object name that breaks out of the quoted identifier.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// pgMaintainVersion is the server version MAINTAIN privileges were
+// introduced in (PostgreSQL 17).
+var pgMaintainVersion = semver.MustParse("17.0.0")
+
+// allPrivilegesForObjectType returns the fully expanded privilege set
+// that `GRANT ALL` / `ALL PRIVILEGES` resolves to for a given
+// object_type on a given server version. Some privileges (MAINTAIN)
+// only exist from a particular server version onward, so the set
+// returned here must stay in sync with the server we're talking to.
+func allPrivilegesForObjectType(objectType string, version semver.Version) []string {
+	switch objectType {
+	case "database":
+		return []string{"CREATE", "CONNECT", "TEMPORARY"}
+	case "schema":
+		return []string{"CREATE", "USAGE"}
+	case "table":
+		privileges := []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+		if version.GE(pgMaintainVersion) {
+			privileges = append(privileges, "MAINTAIN")
+		}
+		return privileges
+	case "sequence":
+		return []string{"USAGE", "SELECT", "UPDATE"}
+	case "function", "procedure":
+		return []string{"EXECUTE"}
+	case "foreign_data_wrapper":
+		return []string{"USAGE"}
+	case "foreign_server":
+		return []string{"USAGE"}
+	case "language":
+		return []string{"USAGE"}
+	default:
+		return nil
+	}
+}
+
+// expandPrivileges resolves a wanted privilege set to its fully
+// expanded form: if the caller asked for the literal sentinel "ALL",
+// it is folded to the concrete privilege list for the object type and
+// server version; otherwise the set is returned untouched.
+func expandPrivileges(wanted *schema.Set, objectType string, version semver.Version) []string {
+	values := setToStringSlice(wanted)
+	if len(values) == 1 && strings.EqualFold(values[0], "ALL") {
+		return allPrivilegesForObjectType(objectType, version)
+	}
+	return values
+}
+
+func setToStringSlice(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	return values
+}
+
+// foldPrivilegesToAll re-folds a granted ACL bitmap back into the
+// `{"ALL"}` sentinel when it exactly matches the fully expanded
+// privilege set for objectType on version. This lets the read path
+// store `ALL` in state instead of the enumerated list whenever a DBA
+// (or another tool) issued a bare `GRANT ALL` out-of-band, avoiding a
+// perpetual diff against a config that also says `ALL`.
+func foldPrivilegesToAll(granted []string, objectType string, version semver.Version) *schema.Set {
+	all := allPrivilegesForObjectType(objectType, version)
+	if privilegeSetsEqual(granted, all) {
+		return schema.NewSet(schema.HashString, []interface{}{"ALL"})
+	}
+
+	values := make([]interface{}, len(granted))
+	for i, p := range granted {
+		values[i] = p
+	}
+	return schema.NewSet(schema.HashString, values)
+}
+
+func privilegeSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(b))
+	for _, p := range b {
+		seen[strings.ToUpper(p)] = true
+	}
+	for _, p := range a {
+		if !seen[strings.ToUpper(p)] {
+			return false
+		}
+	}
+	return true
+}
+
+// resourcePrivilegesEqual reports whether the privileges currently
+// granted on an object (granted) satisfy the privileges the
+// configuration wants (read from d's "privileges" attribute), folding
+// the "ALL" sentinel to its version- and object-type-specific expanded
+// form on both sides of the comparison.
+func resourcePrivilegesEqual(granted *schema.Set, d *schema.ResourceData, version semver.Version) bool {
+	objectType := d.Get("object_type").(string)
+	wanted := d.Get("privileges").(*schema.Set)
+
+	wantedPrivileges := expandPrivileges(wanted, objectType, version)
+	grantedPrivileges := expandPrivileges(granted, objectType, version)
+
+	if len(wantedPrivileges) != len(grantedPrivileges) {
+		return false
+	}
+
+	grantedSet := make(map[string]bool, len(grantedPrivileges))
+	for _, p := range grantedPrivileges {
+		grantedSet[strings.ToUpper(p)] = true
+	}
+	for _, p := range wantedPrivileges {
+		if !grantedSet[strings.ToUpper(p)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldPrivilegesToAll(t *testing.T) {
+	pg16 := semver.MustParse("16.0.0")
+	pg17 := semver.MustParse("17.0.0")
+
+	tests := []struct {
+		name       string
+		granted    []string
+		objectType string
+		version    semver.Version
+		expectAll  bool
+	}{
+		{"full table ACL on PG17 folds to ALL", []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "MAINTAIN"}, "table", pg17, true},
+		{"full table ACL on PG16 has no MAINTAIN but still folds to ALL", []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}, "table", pg16, true},
+		{"PG17 ACL missing MAINTAIN does not fold", []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}, "table", pg17, false},
+		{"partial schema ACL does not fold", []string{"CREATE"}, "schema", pg17, false},
+		{"full schema ACL folds to ALL", []string{"CREATE", "USAGE"}, "schema", pg17, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldPrivilegesToAll(tt.granted, tt.objectType, tt.version)
+			if tt.expectAll {
+				assert.ElementsMatch(t, []interface{}{"ALL"}, folded.List())
+			} else {
+				assert.NotEqual(t, []interface{}{"ALL"}, folded.List())
+			}
+		})
+	}
+}
+
+func TestNormalizeWantedPrivileges(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":           "app",
+		"database":       "appdb",
+		"object_type":    "table",
+		"all_privileges": true,
+	})
+
+	assert.NoError(t, normalizeWantedPrivileges(d))
+	assert.ElementsMatch(t, []interface{}{"ALL"}, d.Get("privileges").(*schema.Set).List())
+}
@@ -0,0 +1,102 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the postgresql Terraform provider.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGHOST", nil),
+				Description: "Name of PostgreSQL server address to connect to.",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGPORT", 5432),
+				Description: "The PostgreSQL port number to connect to at the server host.",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGUSER", nil),
+				Description: "PostgreSQL user name to connect as.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGPASSWORD", nil),
+				Description: "Password to be used if the PostgreSQL server demands password authentication.",
+				Sensitive:   true,
+			},
+			"sslmode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGSSLMODE", "prefer"),
+				Description: "This option determines whether or with what priority a secure SSL TCP/IP connection will be negotiated with the PostgreSQL server.",
+			},
+			"connect_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PGCONNECT_TIMEOUT", 15),
+				Description: "Maximum wait, in seconds, for a new connection to be established.",
+			},
+			"max_open_conns": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "Maximum number of open connections kept per (host, database, user) connection pool. 0 means unlimited.",
+			},
+			"max_idle_conns": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "Maximum number of idle connections kept per (host, database, user) connection pool.",
+			},
+			"conn_max_lifetime": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum lifetime, in seconds, of a pooled connection before it's closed and re-dialed. 0 means connections are reused indefinitely.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"postgresql_grant":              resourcePostgreSQLGrant(),
+			"postgresql_default_privileges": resourcePostgreSQLDefaultPrivileges(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"postgresql_role":     dataSourcePostgreSQLRole(),
+			"postgresql_schema":   dataSourcePostgreSQLSchema(),
+			"postgresql_database": dataSourcePostgreSQLDatabase(),
+			"postgresql_grants":   dataSourcePostgreSQLGrants(),
+		},
+
+		ConfigureContextFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	config := Config{
+		Host:            d.Get("host").(string),
+		Port:            d.Get("port").(int),
+		Username:        d.Get("username").(string),
+		Password:        d.Get("password").(string),
+		SSLMode:         d.Get("sslmode").(string),
+		ApplicationName: "terraform-provider-postgresql",
+		ConnectTimeout:  d.Get("connect_timeout").(int),
+		MaxOpenConns:    d.Get("max_open_conns").(int),
+		MaxIdleConns:    d.Get("max_idle_conns").(int),
+		ConnMaxLifetime: time.Duration(d.Get("conn_max_lifetime").(int)) * time.Second,
+	}
+	return &config, nil
+}
@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileObjectPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		{
+			name:    "percent wildcard",
+			pattern: "myapp_%",
+			matches: []string{"myapp_users", "myapp_"},
+			misses:  []string{"myapp", "other_users"},
+		},
+		{
+			name:    "underscore wildcard",
+			pattern: "staging_db",
+			matches: []string{"staging_db", "stagingxdb"},
+			misses:  []string{"staging__db", "production_db"},
+		},
+		{
+			name:    "escaped percent is literal",
+			pattern: `100\%done`,
+			matches: []string{"100%done"},
+			misses:  []string{"100xdone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := compileObjectPattern(tt.pattern)
+			assert.NoError(t, err)
+
+			for _, m := range tt.matches {
+				assert.True(t, re.MatchString(m), "expected %q to match pattern %q", m, tt.pattern)
+			}
+			for _, m := range tt.misses {
+				assert.False(t, re.MatchString(m), "expected %q not to match pattern %q", m, tt.pattern)
+			}
+		})
+	}
+}
+
+func buildGrantResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, raw)
+}
+
+func TestPrivilegeClause(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":        "app",
+		"database":    "appdb",
+		"object_type": "table",
+		"privileges":  []interface{}{"SELECT", "INSERT"},
+	})
+	assert.Equal(t, "INSERT, SELECT", privilegeClause(d))
+
+	d = buildGrantResourceData(t, map[string]interface{}{
+		"role":           "app",
+		"database":       "appdb",
+		"object_type":    "table",
+		"all_privileges": true,
+	})
+	assert.Equal(t, "ALL PRIVILEGES", privilegeClause(d))
+}
+
+func TestGrantStatementsAllInSchema(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":        "app",
+		"database":    "appdb",
+		"schema":      "public",
+		"object_type": "table",
+		"scope":       "all_in_schema",
+		"privileges":  []interface{}{"SELECT"},
+	})
+
+	statements := grantStatements(d, nil)
+	assert.Equal(t,
+		[]string{`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "app"`},
+		statements,
+	)
+}
+
+func TestGrantStatementsPerObject(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":        "app",
+		"database":    "appdb",
+		"schema":      "public",
+		"object_type": "table",
+		"privileges":  []interface{}{"SELECT"},
+	})
+
+	statements := grantStatements(d, []string{"users", "orders"})
+	assert.ElementsMatch(t,
+		[]string{
+			`GRANT SELECT ON TABLE "public"."users" TO "app"`,
+			`GRANT SELECT ON TABLE "public"."orders" TO "app"`,
+		},
+		statements,
+	)
+}
+
+// TestRevokeStatementsOneStatementPerObject guards against the
+// per-object loop collapsing back into a single whole-type statement:
+// with scope = "object", revokeStatements must emit one REVOKE for
+// each resolved object rather than one regardless of how many objects
+// matched.
+func TestRevokeStatementsOneStatementPerObject(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":        "app",
+		"database":    "appdb",
+		"schema":      "public",
+		"object_type": "table",
+		"privileges":  []interface{}{"SELECT"},
+	})
+
+	objects := []string{"myapp_users", "myapp_orders", "myapp_invoices"}
+	statements := revokeStatements(d, objects)
+
+	assert.Len(t, statements, len(objects), "expected one REVOKE statement per resolved object")
+	for _, object := range objects {
+		assert.Contains(t, statements, `REVOKE ALL PRIVILEGES ON TABLE "public"."`+object+`" FROM "app"`)
+	}
+}
+
+func TestResolveObjectsDefaultScopeHasNoObjects(t *testing.T) {
+	d := buildGrantResourceData(t, map[string]interface{}{
+		"role":        "app",
+		"database":    "appdb",
+		"schema":      "public",
+		"object_type": "table",
+		"scope":       "default",
+		"privileges":  []interface{}{"SELECT"},
+	})
+
+	objects, err := resolveObjects(nil, d)
+	assert.NoError(t, err)
+	assert.Empty(t, objects, "scope = default governs future objects, not any existing object list")
+}